@@ -0,0 +1,95 @@
+// Command littlevm loads a bytecode file and runs it to completion.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/ashmeet28/littlevm/pkg/littlevm"
+	"github.com/ashmeet28/littlevm/pkg/littlevm/disasm"
+)
+
+// traceStackWords is how many 8-byte stack words --trace prints per tick.
+const traceStackWords = 4
+
+// demoThrottle paces a non-traced run to a watchable speed, matching the
+// sleep VMRun used to do unconditionally before Throttle became opt-in.
+// --trace mode leaves VMOpts.Throttle at zero and runs at full speed.
+const demoThrottle = 250 * time.Millisecond
+
+func main() {
+	trace := flag.Bool("trace", false, "single-step and print PC/FP/SP, the disassembled instruction and the top of the stack for every tick")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		log.Fatal("usage: littlevm [--trace] <bytecode file>")
+	}
+
+	data, err := os.ReadFile(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opts := littlevm.DefaultVMOpts()
+	if !*trace {
+		opts.Throttle = demoThrottle
+	}
+
+	vm, err := littlevm.New(data, opts)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
+	if !*trace {
+		if err := vm.Run(); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	for vm.Status == littlevm.VMS_RUNNING {
+		printTraceLine(vm)
+
+		if err := vm.Step(); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+	}
+}
+
+func printTraceLine(vm *littlevm.VM) {
+	instrStr := "<end of program>"
+
+	if instr, err := disasm.Decode(vm.BM, vm.PC, vm.Reachable); err == nil {
+		instrStr = instr.String()
+	} else {
+		instrStr = fmt.Sprintf("<%v>", err)
+	}
+
+	fmt.Printf("pc=%06x fp=%d sp=%d %-40s stack=%v\n", vm.PC, vm.FP, vm.SP, instrStr, topStackWords(vm))
+}
+
+func topStackWords(vm *littlevm.VM) []uint64 {
+	var words []uint64
+
+	sp := vm.SP
+	for i := 0; i < traceStackWords && sp >= 8; i++ {
+		sp -= 8
+		words = append(words, readWord(vm.SM[sp:]))
+	}
+
+	return words
+}
+
+func readWord(b []byte) uint64 {
+	var v uint64
+	for i := 0; i < 8; i++ {
+		v |= uint64(b[i]) << (8 * i)
+	}
+	return v
+}
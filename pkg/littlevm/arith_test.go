@@ -0,0 +1,54 @@
+package littlevm
+
+import "testing"
+
+// signedValInfo returns a valInfo byte for a direct (non-indirect) signed
+// operand of the given size.
+func signedValInfo(size byte) byte {
+	return size | 0b10000
+}
+
+func TestDivRemSigned(t *testing.T) {
+	cases := []struct {
+		size     byte
+		dividend int64
+		divisor  int64
+	}{
+		{1, -7, 8},
+		{1, -7, 3},
+		{1, 7, -3},
+		{1, -128, -1},
+		{2, -12345, 37},
+		{4, -1234567, 1000},
+		{8, -9223372036854775808, -1},
+	}
+
+	for _, c := range cases {
+		b := signedValInfo(c.size)
+
+		mask := maskToSize(^uint64(0), c.size)
+		vj := uint64(c.dividend) & mask
+		vk := uint64(c.divisor) & mask
+
+		wantQ := uint64(c.dividend/c.divisor) & mask
+		wantR := uint64(c.dividend%c.divisor) & mask
+
+		q, r := divRem(b, b, vj, vk)
+		q &= mask
+		r &= mask
+
+		if q != wantQ || r != wantR {
+			t.Errorf("size=%d %d QUO/REM %d: got q=%#x r=%#x, want q=%#x r=%#x",
+				c.size, c.dividend, c.divisor, q, r, wantQ, wantR)
+		}
+	}
+}
+
+func TestDivRemUnsigned(t *testing.T) {
+	b := byte(8) // size 8, unsigned
+
+	q, r := divRem(b, b, 17, 5)
+	if q != 3 || r != 2 {
+		t.Errorf("17 QUO/REM 5: got q=%d r=%d, want q=3 r=2", q, r)
+	}
+}
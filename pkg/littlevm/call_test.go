@@ -0,0 +1,77 @@
+package littlevm
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func putDisp(v int64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(v))
+	return buf[:]
+}
+
+// TestCallToForwardSubroutine exercises the ordinary shape of a subroutine
+// call: an immediate PUSH of the displacement feeding the very next CALL,
+// with the callee's body placed after a HALT. Verify's straight-line
+// fall-through walk never visits the callee (it stops at the CALL, which
+// doesn't fall through), so CALL must not reject the target just because it
+// isn't in the reachable set Verify built.
+func TestCallToForwardSubroutine(t *testing.T) {
+	var bc []byte
+
+	// PC 0..9: PUSH <displacement>
+	// PC 10:   CALL
+	// PC 11:   HALT              (never reached; CALL jumps over it)
+	// PC 12:   HALT              (the callee's body)
+	callPC := uint64(10)
+	calleePC := uint64(12)
+
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], calleePC-callPC)
+
+	bc = append(bc, OP_PUSH, 0b1000)
+	bc = append(bc, buf[:]...)
+	bc = append(bc, OP_CALL)
+	bc = append(bc, OP_HALT)
+	bc = append(bc, OP_HALT)
+
+	vm, err := New(bc, DefaultVMOpts())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if err := vm.Run(); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if vm.PC != calleePC+1 {
+		t.Fatalf("got PC=%d after halting in the callee, want %d", vm.PC, calleePC+1)
+	}
+}
+
+// TestJumpToMisalignedTargetRejected checks the other side of the same
+// coin as TestCallToForwardSubroutine: a target that isn't the start of
+// any instruction at all (here, the middle of a PUSH's own immediate)
+// must still be rejected, even though runtime gating no longer consults
+// the fall-through-only ReachableSet.
+func TestJumpToMisalignedTargetRejected(t *testing.T) {
+	var bc []byte
+
+	// PC 0..9: PUSH <displacement>
+	// PC 10:   JUMP, to PC 10 + (-7) = 3, mid-way through the PUSH's
+	//          8-byte immediate.
+	bc = append(bc, OP_PUSH, 0b1000)
+	bc = append(bc, putDisp(-7)...)
+	bc = append(bc, OP_JUMP)
+	bc = append(bc, OP_HALT)
+
+	vm, err := New(bc, DefaultVMOpts())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if err := vm.Run(); err != ErrInvalidInstruction {
+		t.Fatalf("Run: got %v, want ErrInvalidInstruction", err)
+	}
+}
@@ -0,0 +1,94 @@
+package littlevm
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Default syscall ids, used by DefaultSyscalls.
+const (
+	SyscallPrintString uint32 = 0x01
+	SyscallPrintInt    uint32 = 0x02
+	SyscallReadLine    uint32 = 0x03
+	SyscallExit        uint32 = 0x04
+	SyscallTimeNowNS   uint32 = 0x05
+)
+
+// DefaultSyscalls returns the small set of host functions a fresh VM is
+// given when VMOpts.Syscalls is nil: enough to print, read a line, exit,
+// and read the clock.
+func DefaultSyscalls() map[uint32]Syscall {
+	return map[uint32]Syscall{
+		SyscallPrintString: syscallPrintString,
+		SyscallPrintInt:    syscallPrintInt,
+		SyscallReadLine:    syscallReadLine,
+		SyscallExit:        syscallExit,
+		SyscallTimeNowNS:   syscallTimeNowNS,
+	}
+}
+
+// syscallPrintString(addr) prints the NUL-terminated string starting at
+// addr in MM.
+func syscallPrintString(vm *VM, args ...uint64) (uint64, error) {
+	addr := args[0]
+
+	var i uint64
+	var buf []byte
+
+	for addr+i < uint64(len(vm.MM)) && vm.MM[addr+i] != 0 {
+		buf = append(buf, vm.MM[addr+i])
+		i++
+	}
+
+	fmt.Println(string(buf))
+
+	return 0, nil
+}
+
+// syscallPrintInt(v) prints v as a signed 64-bit integer.
+func syscallPrintInt(vm *VM, args ...uint64) (uint64, error) {
+	fmt.Println(int64(args[0]))
+
+	return 0, nil
+}
+
+// syscallReadLine(addr, maxLen) reads a line from stdin, writes it
+// (without the trailing newline, NUL-terminated) into MM at addr, and
+// returns the number of bytes written excluding the terminator.
+func syscallReadLine(vm *VM, args ...uint64) (uint64, error) {
+	addr, maxLen := args[0], args[1]
+
+	if vm.stdin == nil {
+		vm.stdin = bufio.NewReader(os.Stdin)
+	}
+
+	line, _ := vm.stdin.ReadString('\n')
+
+	for len(line) > 0 && (line[len(line)-1] == '\n' || line[len(line)-1] == '\r') {
+		line = line[:len(line)-1]
+	}
+
+	if uint64(len(line))+1 > maxLen {
+		line = line[:maxLen-1]
+	}
+
+	copy(vm.MM[addr:], line)
+	vm.MM[addr+uint64(len(line))] = 0
+
+	return uint64(len(line)), nil
+}
+
+// syscallExit halts the VM, as if it had executed OP_HALT.
+func syscallExit(vm *VM, args ...uint64) (uint64, error) {
+	vm.Status = VMS_HALT
+
+	return 0, nil
+}
+
+// syscallTimeNowNS returns the current wall-clock time as nanoseconds
+// since the Unix epoch.
+func syscallTimeNowNS(vm *VM, args ...uint64) (uint64, error) {
+	return uint64(time.Now().UnixNano()), nil
+}
@@ -0,0 +1,195 @@
+// Package disasm decodes littlevm bytecode into a printable form, one
+// instruction at a time, for tracers and debuggers.
+package disasm
+
+import (
+	"fmt"
+
+	"github.com/ashmeet28/littlevm/pkg/littlevm"
+)
+
+// Instruction is one decoded instruction.
+type Instruction struct {
+	PC       uint64
+	Size     uint64
+	Mnemonic string
+	Operands string
+
+	// Reachable mirrors littlevm.VM.Reachable(PC): true unless a reachable
+	// predicate was passed to Decode and it reported PC unreachable by
+	// straight-line fall-through from PC 0.
+	Reachable bool
+}
+
+func (i Instruction) String() string {
+	marker := ""
+	if !i.Reachable {
+		marker = " (off static fall-through path)"
+	}
+	if i.Operands == "" {
+		return fmt.Sprintf("%06x: %s%s", i.PC, i.Mnemonic, marker)
+	}
+	return fmt.Sprintf("%06x: %-6s %s%s", i.PC, i.Mnemonic, i.Operands, marker)
+}
+
+var mnemonics = map[byte]string{
+	littlevm.OP_HALT:  "HALT",
+	littlevm.OP_ECALL: "ECALL",
+
+	littlevm.OP_CALL:   "CALL",
+	littlevm.OP_RETURN: "RETURN",
+
+	littlevm.OP_JUMP:   "JUMP",
+	littlevm.OP_BRANCH: "BRANCH",
+
+	littlevm.OP_PUSH:   "PUSH",
+	littlevm.OP_POP:    "POP",
+	littlevm.OP_ASSIGN: "ASSIGN",
+
+	littlevm.OP_ADD: "ADD",
+	littlevm.OP_SUB: "SUB",
+
+	littlevm.OP_AND: "AND",
+	littlevm.OP_OR:  "OR",
+	littlevm.OP_XOR: "XOR",
+
+	littlevm.OP_SHL: "SHL",
+	littlevm.OP_SHR: "SHR",
+
+	littlevm.OP_MUL: "MUL",
+	littlevm.OP_QUO: "QUO",
+	littlevm.OP_REM: "REM",
+
+	littlevm.OP_EQL: "EQL",
+	littlevm.OP_NEQ: "NEQ",
+	littlevm.OP_LSS: "LSS",
+	littlevm.OP_GTR: "GTR",
+	littlevm.OP_LEQ: "LEQ",
+	littlevm.OP_GEQ: "GEQ",
+
+	littlevm.OP_CONVERT: "CONVERT",
+
+	littlevm.OP_LOAD:  "LOAD",
+	littlevm.OP_STORE: "STORE",
+
+	littlevm.OP_STORE_STRING: "STORE_STRING",
+}
+
+// valInfo renders a valInfo byte as e.g. "s64", "u8*" (the "*" marks an
+// indirect/frame-relative operand).
+func valInfo(b byte) string {
+	size := (b & 0b1111) * 8
+
+	sign := "u"
+	if (b & 0b10000) != 0 {
+		sign = "s"
+	}
+
+	ind := ""
+	if (b & 0b100000) != 0 {
+		ind = "*"
+	}
+
+	return fmt.Sprintf("%s%d%s", sign, size, ind)
+}
+
+func valRead(b []byte, s byte) uint64 {
+	var v uint64
+	for i := byte(0); i < s; i++ {
+		v |= uint64(b[i]) << (8 * i)
+	}
+	return v
+}
+
+// Decode decodes the instruction at pc. bm is the full bytecode buffer
+// (VM.BM); pc must point at the start of an instruction. reachable, if
+// non-nil, is consulted to fill in Instruction.Reachable — pass a VM's
+// Reachable method to flag PCs static analysis couldn't reach by
+// fall-through; pass nil to always report pc as reachable.
+func Decode(bm []byte, pc uint64, reachable func(uint64) bool) (Instruction, error) {
+	if pc >= uint64(len(bm)) {
+		return Instruction{}, littlevm.ErrOOB
+	}
+
+	op := bm[pc]
+
+	mnemonic, known := mnemonics[op]
+	if !known {
+		return Instruction{}, littlevm.ErrInvalidInstruction
+	}
+
+	in := Instruction{PC: pc, Mnemonic: mnemonic, Reachable: reachable == nil || reachable(pc)}
+
+	switch op {
+
+	case littlevm.OP_HALT, littlevm.OP_CALL, littlevm.OP_JUMP:
+		in.Size = 1
+		// CALL/JUMP targets are runtime stack values, not immediates, so
+		// there is no static target PC to print here.
+
+	case littlevm.OP_ECALL:
+		if pc+6 > uint64(len(bm)) {
+			return Instruction{}, littlevm.ErrOOB
+		}
+		id := uint32(valRead(bm[pc+1:], 4))
+		argc := bm[pc+5]
+		in.Size = 6
+		in.Operands = fmt.Sprintf("id=0x%x argc=%d", id, argc)
+
+	case littlevm.OP_RETURN, littlevm.OP_LOAD, littlevm.OP_STORE, littlevm.OP_POP:
+		if pc+2 > uint64(len(bm)) {
+			return Instruction{}, littlevm.ErrOOB
+		}
+		in.Size = 2
+		in.Operands = valInfo(bm[pc+1])
+
+	case littlevm.OP_BRANCH:
+		if pc+2 > uint64(len(bm)) {
+			return Instruction{}, littlevm.ErrOOB
+		}
+		in.Size = 2
+		in.Operands = fmt.Sprintf("%s target=<dynamic>", valInfo(bm[pc+1]))
+
+	case littlevm.OP_PUSH:
+		if pc+2 > uint64(len(bm)) {
+			return Instruction{}, littlevm.ErrOOB
+		}
+		b1 := bm[pc+1]
+		size := b1 & 0b1111
+		if pc+2+uint64(size) > uint64(len(bm)) {
+			return Instruction{}, littlevm.ErrOOB
+		}
+		in.Size = 2 + uint64(size)
+		in.Operands = fmt.Sprintf("%s %d", valInfo(b1), valRead(bm[pc+2:], size))
+
+	case littlevm.OP_ASSIGN, littlevm.OP_ADD, littlevm.OP_SUB, littlevm.OP_AND,
+		littlevm.OP_OR, littlevm.OP_XOR, littlevm.OP_SHL, littlevm.OP_SHR,
+		littlevm.OP_MUL, littlevm.OP_QUO, littlevm.OP_REM,
+		littlevm.OP_EQL, littlevm.OP_NEQ, littlevm.OP_LSS, littlevm.OP_GTR,
+		littlevm.OP_LEQ, littlevm.OP_GEQ, littlevm.OP_CONVERT:
+		if pc+3 > uint64(len(bm)) {
+			return Instruction{}, littlevm.ErrOOB
+		}
+		in.Size = 3
+		in.Operands = fmt.Sprintf("%s %s", valInfo(bm[pc+1]), valInfo(bm[pc+2]))
+
+	case littlevm.OP_STORE_STRING:
+		i := uint64(0)
+		for {
+			if pc+i+2 > uint64(len(bm)) {
+				return Instruction{}, littlevm.ErrOOB
+			}
+			if bm[pc+i+1] == 0 {
+				break
+			}
+			i++
+		}
+		in.Size = i + 2
+		in.Operands = fmt.Sprintf("%q", string(bm[pc+1:pc+1+i]))
+
+	default:
+		return Instruction{}, littlevm.ErrInvalidInstruction
+	}
+
+	return in, nil
+}
@@ -0,0 +1,22 @@
+package littlevm
+
+import "errors"
+
+var (
+	// ErrInvalidInstruction is returned when the fetch/decode loop finds an
+	// opcode, operand, or valInfo byte that does not describe a well formed
+	// instruction.
+	ErrInvalidInstruction = errors.New("littlevm: invalid instruction")
+
+	// ErrOOB is returned when an instruction reads or writes outside the
+	// bounds of the memory region it was translated against.
+	ErrOOB = errors.New("littlevm: out of bounds memory access")
+
+	// ErrDivideByZero is returned by OP_QUO and OP_REM when the divisor is
+	// zero.
+	ErrDivideByZero = errors.New("littlevm: divide by zero")
+
+	// ErrCUExceeded is returned once the VM has consumed more compute units
+	// than VMOpts.MaxComputeUnits allows.
+	ErrCUExceeded = errors.New("littlevm: compute unit budget exceeded")
+)
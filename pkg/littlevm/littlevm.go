@@ -0,0 +1,967 @@
+// Package littlevm implements a small stack-based bytecode VM: fetch/decode
+// loop, typed values, and a syscall ("ECALL") boundary for host-provided
+// functionality. See cmd/littlevm for a binary that loads a bytecode file
+// and runs it.
+package littlevm
+
+import (
+	"bufio"
+	"fmt"
+	"time"
+)
+
+// VM is one instance of the interpreter. Create one with New; a VM is not
+// safe for concurrent use by multiple goroutines, but independent VMs may
+// run concurrently.
+type VM struct {
+	MM []byte // Main Memory
+	BM []byte // Bytecode Memory
+	SM []byte // Stack Memory
+
+	PC uint64 // Program Counter
+	FP uint64 // Frame Pointer
+	SP uint64 // Stack Pointer
+
+	Status int
+
+	opts VMOpts
+
+	cu uint64
+
+	reachable  ReachableSet
+	boundaries BoundarySet
+
+	// stdin backs the default read_line syscall. It's per-VM, lazily
+	// created, so concurrent VMs don't share a bufio.Reader.
+	stdin *bufio.Reader
+}
+
+const (
+	VMS_ILLEGAL int = iota
+
+	VMS_HALT
+	VMS_RUNNING
+	VMS_ECALL
+)
+
+var (
+	OP_HALT  byte = 0x01
+	OP_ECALL byte = 0x02
+
+	OP_CALL   byte = 0x04
+	OP_RETURN byte = 0x05
+
+	OP_JUMP   byte = 0x08
+	OP_BRANCH byte = 0x09
+
+	OP_PUSH   byte = 0x0c
+	OP_POP    byte = 0x0d
+	OP_ASSIGN byte = 0x0e
+
+	OP_ADD byte = 0x40
+	OP_SUB byte = 0x41
+
+	OP_AND byte = 0x44
+	OP_OR  byte = 0x45
+	OP_XOR byte = 0x46
+
+	OP_SHL byte = 0x48
+	OP_SHR byte = 0x49
+
+	OP_MUL byte = 0x4c
+	OP_QUO byte = 0x4d
+	OP_REM byte = 0x4e
+
+	OP_EQL byte = 0x50
+	OP_NEQ byte = 0x51
+	OP_LSS byte = 0x52
+	OP_GTR byte = 0x53
+	OP_LEQ byte = 0x54
+	OP_GEQ byte = 0x55
+
+	OP_CONVERT byte = 0x58
+
+	OP_LOAD  byte = 0x20
+	OP_STORE byte = 0x21
+
+	OP_STORE_STRING byte = 0x22
+)
+
+// New allocates a VM ready to run the given bytecode, sized and configured
+// by opts. Use DefaultVMOpts for sane defaults.
+func New(bytecode []byte, opts VMOpts) (*VM, error) {
+	vm := &VM{
+		MM: make([]byte, opts.MainMemSize),
+
+		BM: make([]byte, opts.BytecodeMemSize),
+		SM: make([]byte, opts.StackMemSize),
+
+		PC: 0,
+		FP: opts.InitialStackSize,
+		SP: opts.InitialStackSize,
+
+		Status: VMS_RUNNING,
+
+		opts: opts,
+	}
+
+	if vm.opts.Syscalls == nil {
+		vm.opts.Syscalls = DefaultSyscalls()
+	}
+
+	if len(bytecode) > len(vm.BM) {
+		return nil, fmt.Errorf("littlevm: bytecode size %d exceeds BM size %d", len(bytecode), len(vm.BM))
+	}
+
+	reachable, boundaries, err := Verify(bytecode)
+	if err != nil {
+		return nil, err
+	}
+	vm.reachable = reachable
+	vm.boundaries = boundaries
+
+	copy(vm.BM, bytecode)
+
+	return vm, nil
+}
+
+// Reachable reports whether pc was found reachable by straight-line
+// fall-through from PC 0 at load time. CALL, JUMP and BRANCH targets are
+// ordinary runtime values computed from the stack, so a target outside
+// this set may still be the entry point of a subroutine placed after a
+// HALT/CALL/RETURN/JUMP, which Verify's fall-through walk can't see ahead
+// of time; the interpreter does not gate execution on it. It's exposed
+// for a disassembler or tracer to flag code paths static analysis
+// couldn't reach, as disasm.Decode and cmd/littlevm's --trace mode do.
+//
+// CALL/JUMP/BRANCH targets are instead checked against the complete
+// instruction-boundary set Verify builds (see BoundarySet): landing
+// outside it is always invalid, landing inside it never is, regardless
+// of fall-through reachability.
+func (vm *VM) Reachable(pc uint64) bool {
+	return vm.reachable[pc]
+}
+
+func valRead(b []byte, s byte) uint64 {
+	var v uint64
+	var i byte
+
+	for i = 0; i < s; i++ {
+		v = v | (uint64(b[i]) << (8 * i))
+	}
+
+	return v
+}
+
+func valWrite(b []byte, s byte, v uint64) []byte {
+	var i byte
+
+	for i = 0; i < s; i++ {
+		b[i] = byte((v >> (8 * i)) & 0xff)
+	}
+
+	return b
+}
+
+func valInfoIsValid(b byte) bool {
+	if (b & 0b11000000) != 0 {
+		return false
+	}
+
+	s := (b & 0b1111)
+
+	return ((s == 1) || (s == 2) || (s == 4) || (s == 8))
+}
+
+func valInfoSize(b byte) byte {
+	return (b & 0b1111)
+}
+
+func valInfoIsSigned(b byte) bool {
+	return ((b & 0b10000) == 0b10000)
+}
+
+func valInfoIsIndirect(b byte) bool {
+	return ((b & 0b100000) == 0b100000)
+}
+
+func valSignBit(v uint64, s byte) byte {
+	if (v & (uint64(1) << ((uint64(s) * 8) - 1))) == (uint64(1) << ((uint64(s) * 8) - 1)) {
+		return 1
+	} else {
+		return 0
+	}
+}
+
+func (vm *VM) popVal(valInfo byte) (uint64, error) {
+	var v uint64
+
+	if valInfoIsIndirect(valInfo) {
+		va := valRead(vm.SM[vm.SP-8:], 8)
+		vm.SP -= 8
+
+		v = valRead(vm.SM[vm.FP+va:], valInfoSize(valInfo))
+	} else {
+		v = valRead(vm.SM[vm.SP-uint64(valInfoSize(valInfo)):], valInfoSize(valInfo))
+
+		vm.SP = vm.SP - uint64(valInfoSize(valInfo))
+	}
+
+	return v, nil
+}
+
+// Tick executes a single instruction. It returns an error if the bytecode
+// is malformed, an access is out of bounds, or the VM's compute unit
+// budget has been exhausted; otherwise it leaves vm.Status set to
+// VMS_RUNNING or VMS_HALT for the caller (normally Run) to act on. ECALLs
+// are dispatched within the tick itself. Tick never panics: an out-of-range access that slips past Verify
+// (e.g. an instruction that underflows the stack) comes back as ErrOOB
+// instead.
+func (vm *VM) Tick() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = ErrOOB
+		}
+	}()
+
+	return vm.tick()
+}
+
+func (vm *VM) tick() error {
+	if vm.opts.Tracer != nil {
+		vm.opts.Tracer.Printf("pc=%06x fp=%d sp=%d op=0x%02x", vm.PC, vm.FP, vm.SP, vm.BM[vm.PC])
+	}
+
+	if vm.opts.MaxComputeUnits != 0 {
+		vm.cu++
+
+		if vm.cu > vm.opts.MaxComputeUnits {
+			return ErrCUExceeded
+		}
+	}
+
+	switch vm.BM[vm.PC] {
+
+	case OP_HALT:
+
+		vm.Status = VMS_HALT
+		vm.PC += 1
+
+	case OP_ECALL:
+
+		if vm.PC+6 > uint64(len(vm.BM)) {
+			return ErrOOB
+		}
+
+		id := uint32(valRead(vm.BM[vm.PC+1:], 4))
+		argc := vm.BM[vm.PC+5]
+
+		fn, ok := vm.opts.Syscalls[id]
+		if !ok {
+			return ErrInvalidInstruction
+		}
+
+		args := make([]uint64, argc)
+
+		var i byte
+		for i = argc; i > 0; i-- {
+			v, err := vm.popVal(0b01000)
+			if err != nil {
+				return err
+			}
+
+			args[i-1] = v
+		}
+
+		if vm.opts.Tracer != nil {
+			vm.opts.Tracer.Printf("ecall id=0x%x args=%v pc=%d fp=%d sp=%d", id, args, vm.PC, vm.FP, vm.SP)
+		}
+
+		ret, err := fn(vm, args...)
+		if err != nil {
+			return err
+		}
+
+		valWrite(vm.SM[vm.SP:], 8, ret)
+		vm.SP += 8
+
+		vm.PC += 6
+
+	case OP_CALL:
+
+		va := valRead(vm.SM[vm.SP-8:], 8)
+		vm.SP -= 8
+
+		target := vm.PC + va
+		if !vm.boundaries[target] {
+			return ErrInvalidInstruction
+		}
+
+		valWrite(vm.SM[vm.SP:], 8, vm.FP)
+		vm.SP += 8
+		valWrite(vm.SM[vm.SP:], 8, vm.PC+1)
+		vm.SP += 8
+
+		vm.FP = vm.SP
+		vm.PC = target
+
+	case OP_RETURN:
+
+		va := valRead(vm.SM[vm.SP-8:], 8)
+		vm.SP -= 8
+
+		vx := valRead(vm.SM[vm.FP-8:], 8)
+		vy := valRead(vm.SM[vm.FP-16:], 8)
+
+		b1 := vm.BM[vm.PC+1]
+
+		if b1 == 0 {
+
+			vm.SP = va + vm.FP
+
+		} else {
+
+			if !valInfoIsValid(b1) {
+				return ErrInvalidInstruction
+			}
+
+			vj, err := vm.popVal(b1)
+			if err != nil {
+				return err
+			}
+
+			vm.SP = va + vm.FP
+
+			valWrite(vm.SM[vm.SP:], valInfoSize(b1), vj)
+			vm.SP = vm.SP + uint64(valInfoSize(b1))
+
+		}
+
+		vm.PC = vx
+		vm.FP = vy
+
+	case OP_JUMP:
+
+		va := valRead(vm.SM[vm.SP-8:], 8)
+		vm.SP -= 8
+
+		target := vm.PC + va
+		if !vm.boundaries[target] {
+			return ErrInvalidInstruction
+		}
+
+		vm.PC = target
+
+	case OP_BRANCH:
+
+		b1 := vm.BM[vm.PC+1]
+
+		if !valInfoIsValid(b1) {
+			return ErrInvalidInstruction
+		}
+
+		va := valRead(vm.SM[vm.SP-8:], 8)
+		vm.SP -= 8
+
+		vj, err := vm.popVal(b1)
+		if err != nil {
+			return err
+		}
+
+		if vj == 0 {
+			target := vm.PC + va
+			if !vm.boundaries[target] {
+				return ErrInvalidInstruction
+			}
+			vm.PC = target
+		} else {
+			vm.PC += 2
+		}
+
+	case OP_PUSH:
+
+		b1 := vm.BM[vm.PC+1]
+
+		if !valInfoIsValid(b1) {
+			return ErrInvalidInstruction
+		}
+
+		if valInfoIsIndirect(b1) {
+			return ErrInvalidInstruction
+		}
+
+		valWrite(vm.SM[vm.SP:], valInfoSize(b1), valRead(vm.BM[vm.PC+2:], valInfoSize(b1)))
+
+		vm.SP = vm.SP + uint64(valInfoSize(b1))
+		vm.PC = vm.PC + 2 + uint64(valInfoSize(b1))
+
+	case OP_POP:
+
+		b1 := vm.BM[vm.PC+1]
+
+		if !valInfoIsValid(b1) {
+			return ErrInvalidInstruction
+		}
+
+		if valInfoIsIndirect(b1) {
+			return ErrInvalidInstruction
+		}
+
+		vm.SP = vm.SP - uint64(valInfoSize(b1))
+		vm.PC += 2
+
+	case OP_ASSIGN:
+
+		b1 := vm.BM[vm.PC+1]
+		b2 := vm.BM[vm.PC+2]
+
+		if !(valInfoIsValid(b1) && valInfoIsValid(b2)) {
+			return ErrInvalidInstruction
+		}
+
+		if (b1 & 0b11111) != (b2 & 0b11111) {
+			return ErrInvalidInstruction
+		}
+
+		if !valInfoIsIndirect(b1) {
+			return ErrInvalidInstruction
+		}
+
+		vk, err := vm.popVal(b2)
+		if err != nil {
+			return err
+		}
+
+		va := valRead(vm.SM[vm.SP-8:], 8)
+		vm.SP -= 8
+
+		valWrite(vm.SM[vm.FP+va:], valInfoSize(b2), vk)
+
+		vm.PC += 3
+
+	case OP_ADD:
+
+		b1 := vm.BM[vm.PC+1]
+		b2 := vm.BM[vm.PC+2]
+
+		if !(valInfoIsValid(b1) && valInfoIsValid(b2)) {
+			return ErrInvalidInstruction
+		}
+
+		if (b1 & 0b11111) != (b2 & 0b11111) {
+			return ErrInvalidInstruction
+		}
+
+		vk, err := vm.popVal(b2)
+		if err != nil {
+			return err
+		}
+		vj, err := vm.popVal(b1)
+		if err != nil {
+			return err
+		}
+
+		valWrite(vm.SM[vm.SP:], valInfoSize(b1), vj+vk)
+		vm.SP = vm.SP + uint64(valInfoSize(b1))
+
+		vm.PC += 3
+
+	case OP_SUB:
+
+		b1 := vm.BM[vm.PC+1]
+		b2 := vm.BM[vm.PC+2]
+
+		if !(valInfoIsValid(b1) && valInfoIsValid(b2)) {
+			return ErrInvalidInstruction
+		}
+
+		if (b1 & 0b11111) != (b2 & 0b11111) {
+			return ErrInvalidInstruction
+		}
+
+		vk, err := vm.popVal(b2)
+		if err != nil {
+			return err
+		}
+		vj, err := vm.popVal(b1)
+		if err != nil {
+			return err
+		}
+
+		valWrite(vm.SM[vm.SP:], valInfoSize(b1), vj+((^vk)+1))
+		vm.SP = vm.SP + uint64(valInfoSize(b1))
+
+		vm.PC += 3
+
+	case OP_AND:
+
+		b1 := vm.BM[vm.PC+1]
+		b2 := vm.BM[vm.PC+2]
+
+		if !(valInfoIsValid(b1) && valInfoIsValid(b2)) {
+			return ErrInvalidInstruction
+		}
+
+		if (b1 & 0b11111) != (b2 & 0b11111) {
+			return ErrInvalidInstruction
+		}
+
+		vk, err := vm.popVal(b2)
+		if err != nil {
+			return err
+		}
+		vj, err := vm.popVal(b1)
+		if err != nil {
+			return err
+		}
+
+		valWrite(vm.SM[vm.SP:], valInfoSize(b1), vj&vk)
+		vm.SP = vm.SP + uint64(valInfoSize(b1))
+
+		vm.PC += 3
+
+	case OP_OR:
+
+		b1 := vm.BM[vm.PC+1]
+		b2 := vm.BM[vm.PC+2]
+
+		if !(valInfoIsValid(b1) && valInfoIsValid(b2)) {
+			return ErrInvalidInstruction
+		}
+
+		if (b1 & 0b11111) != (b2 & 0b11111) {
+			return ErrInvalidInstruction
+		}
+
+		vk, err := vm.popVal(b2)
+		if err != nil {
+			return err
+		}
+		vj, err := vm.popVal(b1)
+		if err != nil {
+			return err
+		}
+
+		valWrite(vm.SM[vm.SP:], valInfoSize(b1), vj|vk)
+		vm.SP = vm.SP + uint64(valInfoSize(b1))
+
+		vm.PC += 3
+
+	case OP_XOR:
+
+		b1 := vm.BM[vm.PC+1]
+		b2 := vm.BM[vm.PC+2]
+
+		if !(valInfoIsValid(b1) && valInfoIsValid(b2)) {
+			return ErrInvalidInstruction
+		}
+
+		if (b1 & 0b11111) != (b2 & 0b11111) {
+			return ErrInvalidInstruction
+		}
+
+		vk, err := vm.popVal(b2)
+		if err != nil {
+			return err
+		}
+		vj, err := vm.popVal(b1)
+		if err != nil {
+			return err
+		}
+
+		valWrite(vm.SM[vm.SP:], valInfoSize(b1), vj^vk)
+		vm.SP = vm.SP + uint64(valInfoSize(b1))
+
+		vm.PC += 3
+
+	case OP_SHL:
+
+		b1 := vm.BM[vm.PC+1]
+		b2 := vm.BM[vm.PC+2]
+
+		if !(valInfoIsValid(b1) && valInfoIsValid(b2)) {
+			return ErrInvalidInstruction
+		}
+
+		vk, err := vm.popVal(b2)
+		if err != nil {
+			return err
+		}
+		vj, err := vm.popVal(b1)
+		if err != nil {
+			return err
+		}
+
+		if valInfoIsSigned(b2) && (valSignBit(vk, valInfoSize(b2)) == 1) {
+			return ErrInvalidInstruction
+		}
+
+		valWrite(vm.SM[vm.SP:], valInfoSize(b1), vj<<vk)
+		vm.SP = vm.SP + uint64(valInfoSize(b1))
+
+		vm.PC += 3
+
+	case OP_SHR:
+
+		b1 := vm.BM[vm.PC+1]
+		b2 := vm.BM[vm.PC+2]
+
+		if !(valInfoIsValid(b1) && valInfoIsValid(b2)) {
+			return ErrInvalidInstruction
+		}
+
+		vk, err := vm.popVal(b2)
+		if err != nil {
+			return err
+		}
+		vj, err := vm.popVal(b1)
+		if err != nil {
+			return err
+		}
+
+		if valInfoIsSigned(b2) && (valSignBit(vk, valInfoSize(b2)) == 1) {
+			return ErrInvalidInstruction
+		}
+
+		var vl uint64
+
+		if valInfoIsSigned(b1) && (valSignBit(vj, valInfoSize(b1)) == 1) {
+
+			if vk > uint64(valInfoSize(b1)) {
+				vl = (^uint64(0))
+			} else {
+				vl = (vj >> vk) | ((^uint64(0)) << ((uint64(valInfoSize(b1)) * 8) - vk))
+			}
+
+		} else {
+			vl = vj >> vk
+		}
+
+		valWrite(vm.SM[vm.SP:], valInfoSize(b1), vl)
+		vm.SP = vm.SP + uint64(valInfoSize(b1))
+
+		vm.PC += 3
+
+	case OP_MUL:
+
+		b1 := vm.BM[vm.PC+1]
+		b2 := vm.BM[vm.PC+2]
+
+		if !(valInfoIsValid(b1) && valInfoIsValid(b2)) {
+			return ErrInvalidInstruction
+		}
+
+		if (b1 & 0b11111) != (b2 & 0b11111) {
+			return ErrInvalidInstruction
+		}
+
+		vk, err := vm.popVal(b2)
+		if err != nil {
+			return err
+		}
+		vj, err := vm.popVal(b1)
+		if err != nil {
+			return err
+		}
+
+		valWrite(vm.SM[vm.SP:], valInfoSize(b1), vj*vk)
+		vm.SP = vm.SP + uint64(valInfoSize(b1))
+
+		vm.PC += 3
+
+	case OP_QUO, OP_REM:
+
+		b1 := vm.BM[vm.PC+1]
+		b2 := vm.BM[vm.PC+2]
+
+		if !(valInfoIsValid(b1) && valInfoIsValid(b2)) {
+			return ErrInvalidInstruction
+		}
+
+		if (b1 & 0b11111) != (b2 & 0b11111) {
+			return ErrInvalidInstruction
+		}
+
+		vk, err := vm.popVal(b2)
+		if err != nil {
+			return err
+		}
+		vj, err := vm.popVal(b1)
+		if err != nil {
+			return err
+		}
+
+		if vk == 0 {
+			return ErrDivideByZero
+		}
+
+		q, r := divRem(b1, b2, vj, vk)
+
+		vl := q
+		if vm.BM[vm.PC] == OP_REM {
+			vl = r
+		}
+
+		valWrite(vm.SM[vm.SP:], valInfoSize(b1), vl)
+		vm.SP = vm.SP + uint64(valInfoSize(b1))
+
+		vm.PC += 3
+
+	case OP_EQL, OP_NEQ, OP_LSS, OP_GTR, OP_LEQ, OP_GEQ:
+
+		b1 := vm.BM[vm.PC+1]
+		b2 := vm.BM[vm.PC+2]
+
+		if !(valInfoIsValid(b1) && valInfoIsValid(b2)) {
+			return ErrInvalidInstruction
+		}
+
+		if (b1 & 0b11111) != (b2 & 0b11111) {
+			return ErrInvalidInstruction
+		}
+
+		vk, err := vm.popVal(b2)
+		if err != nil {
+			return err
+		}
+		vj, err := vm.popVal(b1)
+		if err != nil {
+			return err
+		}
+
+		vl := compare(vm.BM[vm.PC], vj, vk, b1, b2)
+
+		valWrite(vm.SM[vm.SP:], 1, vl)
+		vm.SP += 1
+
+		vm.PC += 3
+
+	case OP_CONVERT:
+
+		b1 := vm.BM[vm.PC+1]
+		b2 := vm.BM[vm.PC+2]
+
+		if !(valInfoIsValid(b1) && valInfoIsValid(b2)) {
+			return ErrInvalidInstruction
+		}
+
+		if valInfoIsIndirect(b2) {
+			return ErrInvalidInstruction
+		}
+
+		vj, err := vm.popVal(b1)
+		if err != nil {
+			return err
+		}
+
+		if valInfoIsSigned(b1) && (valSignBit(vj, valInfoSize(b1)) == 1) {
+			vj = vj | ((^uint64(0)) << (uint64(valInfoSize(b1)) * 8))
+		}
+
+		valWrite(vm.SM[vm.SP:], valInfoSize(b2), vj)
+		vm.SP = vm.SP + uint64(valInfoSize(b2))
+
+		vm.PC += 3
+
+	case OP_LOAD:
+
+		b1 := vm.BM[vm.PC+1]
+
+		if !valInfoIsValid(b1) {
+			return ErrInvalidInstruction
+		}
+
+		if valInfoIsIndirect(b1) {
+			return ErrInvalidInstruction
+		}
+
+		va := valRead(vm.SM[vm.SP-8:], 8)
+		vm.SP -= 8
+
+		buf, err := vm.translate(va, valInfoSize(b1))
+		if err != nil {
+			return err
+		}
+
+		valWrite(vm.SM[vm.SP:], valInfoSize(b1), valRead(buf, valInfoSize(b1)))
+		vm.SP = vm.SP + uint64(valInfoSize(b1))
+
+		vm.PC += 2
+
+	case OP_STORE:
+
+		b1 := vm.BM[vm.PC+1]
+
+		if !valInfoIsValid(b1) {
+			return ErrInvalidInstruction
+		}
+
+		if valInfoIsIndirect(b1) {
+			return ErrInvalidInstruction
+		}
+
+		vk, err := vm.popVal(b1)
+		if err != nil {
+			return err
+		}
+
+		va := valRead(vm.SM[vm.SP-8:], 8)
+		vm.SP -= 8
+
+		buf, err := vm.translate(va, valInfoSize(b1))
+		if err != nil {
+			return err
+		}
+
+		valWrite(buf, valInfoSize(b1), vk)
+
+		vm.PC += 2
+
+	case OP_STORE_STRING:
+
+		b1 := byte(0b101000)
+
+		vj, err := vm.popVal(b1)
+		if err != nil {
+			return err
+		}
+
+		if (vj >> 20) != 0x3 {
+			return ErrOOB
+		}
+
+		var i uint64
+
+		for i = 0; vm.BM[vm.PC+i+1] != 0; i += 1 {
+			vm.MM[vj+i] = vm.BM[vm.PC+i+1]
+		}
+
+		vm.MM[vj+i] = 0
+
+		vm.PC = vm.PC + i + 2
+
+	default:
+		return ErrInvalidInstruction
+	}
+
+	return nil
+}
+
+// maskToSize clears every bit above the low size*8 bits, so a negated
+// value keeps only the magnitude that fits in the operand's own width
+// instead of bleeding into the upper bits of the uint64 it's stored in.
+func maskToSize(v uint64, size byte) uint64 {
+	if size >= 8 {
+		return v
+	}
+
+	return v & ((uint64(1) << (uint64(size) * 8)) - 1)
+}
+
+// divRem computes vj/vk and vj%vk, honoring b1/b2's signedness. Signed
+// operands are negated to their unsigned magnitude before Go's / and % run
+// (which would otherwise treat them as huge positive numbers), then the
+// quotient and remainder signs are re-applied: the quotient is negative
+// iff exactly one operand was negative, and the remainder takes the sign
+// of the dividend, matching C/Go semantics (e.g. -7 % 3 == -1).
+func divRem(b1, b2 byte, vj, vk uint64) (q, r uint64) {
+	if !valInfoIsSigned(b1) {
+		return vj / vk, vj % vk
+	}
+
+	vjs := valSignBit(vj, valInfoSize(b1)) == 1
+	vks := valSignBit(vk, valInfoSize(b2)) == 1
+
+	uj, uk := vj, vk
+
+	if vjs {
+		uj = maskToSize((^uj)+1, valInfoSize(b1))
+	}
+	if vks {
+		uk = maskToSize((^uk)+1, valInfoSize(b2))
+	}
+
+	q, r = uj/uk, uj%uk
+
+	if vjs != vks {
+		q = (^q) + 1
+	}
+	if vjs {
+		r = (^r) + 1
+	}
+
+	return q, r
+}
+
+func compare(op byte, vj, vk uint64, b1, b2 byte) uint64 {
+	var lt, gt, eq bool
+
+	if valInfoIsSigned(b1) {
+
+		vjs := (valSignBit(vj, valInfoSize(b1)) == 1)
+		vks := (valSignBit(vk, valInfoSize(b2)) == 1)
+
+		switch {
+		case vjs && !vks:
+			lt, gt = true, false
+		case !vjs && vks:
+			lt, gt = false, true
+		default:
+			lt, gt = vj < vk, vj > vk
+		}
+
+	} else {
+		lt, gt = vj < vk, vj > vk
+	}
+
+	eq = vj == vk
+
+	var result bool
+
+	switch op {
+	case OP_EQL:
+		result = eq
+	case OP_NEQ:
+		result = !eq
+	case OP_LSS:
+		result = lt
+	case OP_GTR:
+		result = gt
+	case OP_LEQ:
+		result = lt || eq
+	case OP_GEQ:
+		result = gt || eq
+	}
+
+	if result {
+		return 1
+	}
+	return 0
+}
+
+// Run executes the VM until it halts or an error occurs. ECALL dispatch is
+// handled internally; see VMOpts.Syscalls.
+func (vm *VM) Run() error {
+	for vm.Status == VMS_RUNNING {
+		if err := vm.Tick(); err != nil {
+			return err
+		}
+
+		if vm.opts.Throttle != 0 {
+			time.Sleep(vm.opts.Throttle)
+		}
+	}
+
+	return nil
+}
+
+// Step executes a single instruction, like Tick, but is a no-op once the
+// VM has halted instead of reading past the end of the program. It's meant
+// for debugger-style callers that drive the VM one instruction at a time.
+func (vm *VM) Step() error {
+	if vm.Status != VMS_RUNNING {
+		return nil
+	}
+
+	return vm.Tick()
+}
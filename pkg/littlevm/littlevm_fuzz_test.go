@@ -0,0 +1,58 @@
+package littlevm
+
+import "testing"
+
+// FuzzRun feeds arbitrary bytes in as bytecode and checks that Run never
+// panics: malformed programs must be caught by Verify, and anything that
+// gets past it must surface as a typed error instead of an index-out-of-
+// range or similar crash.
+func FuzzRun(f *testing.F) {
+	f.Add([]byte{OP_HALT})
+	f.Add([]byte{OP_PUSH, 0b1000, 1, 0, 0, 0, 0, 0, 0, 0, OP_HALT})
+	f.Add([]byte{OP_CALL})
+
+	f.Fuzz(func(t *testing.T, bytecode []byte) {
+		opts := DefaultVMOpts()
+		opts.MaxComputeUnits = 10_000
+
+		vm, err := New(bytecode, opts)
+		if err != nil {
+			return
+		}
+
+		_ = vm.Run() // errors are expected; panics are the only failure mode
+	})
+}
+
+// FuzzSnapshotRoundTrip checks that a VM's PC/FP/SP/Status survive a
+// Snapshot/Restore round trip after running a bounded number of ticks.
+func FuzzSnapshotRoundTrip(f *testing.F) {
+	f.Add([]byte{OP_HALT})
+	f.Add([]byte{OP_PUSH, 0b1000, 1, 0, 0, 0, 0, 0, 0, 0, OP_HALT})
+
+	f.Fuzz(func(t *testing.T, bytecode []byte) {
+		opts := DefaultVMOpts()
+		opts.MaxComputeUnits = 1_000
+
+		vm, err := New(bytecode, opts)
+		if err != nil {
+			return
+		}
+
+		for i := 0; i < 10 && vm.Status == VMS_RUNNING; i++ {
+			if vm.Tick() != nil {
+				return
+			}
+		}
+
+		restored, err := Restore(vm.Snapshot())
+		if err != nil {
+			t.Fatalf("Restore: %v", err)
+		}
+
+		if restored.PC != vm.PC || restored.FP != vm.FP || restored.SP != vm.SP || restored.Status != vm.Status {
+			t.Fatalf("round trip mismatch: got PC=%d FP=%d SP=%d Status=%d, want PC=%d FP=%d SP=%d Status=%d",
+				restored.PC, restored.FP, restored.SP, restored.Status, vm.PC, vm.FP, vm.SP, vm.Status)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package littlevm
+
+import "time"
+
+// Tracer receives a formatted line for every notable event the VM produces
+// (every tick, whether driven by Run, Step or Tick, and every syscall
+// dispatch).
+type Tracer interface {
+	Printf(format string, args ...any)
+}
+
+// VMOpts configures a VM at construction time. The zero value is not
+// ready to use; call DefaultVMOpts and override individual fields.
+type VMOpts struct {
+	// MainMemSize, BytecodeMemSize and StackMemSize size the MM, BM and SM
+	// regions respectively. They replace the old hard-coded 16 MiB regions.
+	MainMemSize     uint64
+	BytecodeMemSize uint64
+	StackMemSize    uint64
+
+	// InitialStackSize reserves the bottom of SM (SP/FP start here instead
+	// of at zero) so a program's first frame has room for locals before
+	// any CALL.
+	InitialStackSize uint64
+
+	// MaxComputeUnits caps the number of ticks Run/Step/Tick will execute
+	// before returning ErrCUExceeded. Zero means unlimited.
+	MaxComputeUnits uint64
+
+	// Tracer, if set, is notified of every tick and every syscall. Nil
+	// disables tracing.
+	Tracer Tracer
+
+	// Syscalls is consulted by OP_ECALL to resolve a syscall id to its
+	// implementation. Nil falls back to DefaultSyscalls().
+	Syscalls map[uint32]Syscall
+
+	// Throttle, if nonzero, is slept after every tick in Run. It exists so
+	// a demo can run at a watchable pace; tracing at full speed just
+	// leaves it zero.
+	Throttle time.Duration
+}
+
+// DefaultVMOpts returns the VMOpts used when embedders don't need anything
+// unusual: 16 MiB regions, no CU cap, no tracer, and the built-in demo
+// syscalls.
+func DefaultVMOpts() VMOpts {
+	return VMOpts{
+		MainMemSize:      0x1000000,
+		BytecodeMemSize:  0x1000000,
+		StackMemSize:     0x1000000,
+		InitialStackSize: 0,
+		MaxComputeUnits:  0,
+		Tracer:           nil,
+		Syscalls:         nil,
+	}
+}
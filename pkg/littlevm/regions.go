@@ -0,0 +1,73 @@
+package littlevm
+
+// Named MM regions. Every virtual address an OP_LOAD/OP_STORE resolves
+// goes through one of these; addresses that don't land inside any region,
+// or whose access size would run past the region's end, are rejected with
+// ErrOOB. OP_STORE_STRING only checks that its destination starts in the
+// mmio region and then writes MM directly, and syscalls index MM directly
+// without consulting this table. The layout is fixed rather than
+// configurable so that bytecode compiled against one VM runs unmodified
+// against another.
+const (
+	regionRodataBase = 0x00_0000
+	regionRodataSize = 0x10_0000
+
+	regionHeapBase = 0x10_0000
+	regionHeapSize = 0x20_0000
+
+	// regionMMIOBase intentionally matches the address range OP_STORE_STRING
+	// accepts as a destination (0x300000-0x3fffff), so strings it writes
+	// there can be handed to the print_string syscall.
+	regionMMIOBase = 0x30_0000
+	regionMMIOSize = 0x10_0000
+
+	regionStackMirrorBase = 0x40_0000
+)
+
+type region struct {
+	name string
+	base uint64
+	size uint64
+}
+
+// regions returns the region table for this VM. The stack-mirror region's
+// size tracks len(vm.SM) so the whole stack is addressable through it.
+func (vm *VM) regions() [4]region {
+	return [4]region{
+		{"rodata", regionRodataBase, regionRodataSize},
+		{"heap", regionHeapBase, regionHeapSize},
+		{"mmio", regionMMIOBase, regionMMIOSize},
+		{"stack-mirror", regionStackMirrorBase, uint64(len(vm.SM))},
+	}
+}
+
+// translate resolves a virtual address plus access size to the backing
+// byte slice it should be read from or written to, bounds-checking against
+// the owning region.
+func (vm *VM) translate(va uint64, size byte) ([]byte, error) {
+	sz := uint64(size)
+
+	for _, r := range vm.regions() {
+		if va < r.base || va-r.base >= r.size {
+			continue
+		}
+
+		off := va - r.base
+
+		if off+sz > r.size {
+			return nil, ErrOOB
+		}
+
+		if r.name == "stack-mirror" {
+			return vm.SM[off : off+sz], nil
+		}
+
+		if va+sz > uint64(len(vm.MM)) {
+			return nil, ErrOOB
+		}
+
+		return vm.MM[va : va+sz], nil
+	}
+
+	return nil, ErrOOB
+}
@@ -0,0 +1,148 @@
+package littlevm
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// snapshotMagic and snapshotVersion identify the format Snapshot/Restore
+// speak; Restore rejects anything else so a format change can't be
+// silently misread.
+const (
+	snapshotMagic   = "LVMSNAP\x00"
+	snapshotVersion = 1
+)
+
+// Snapshot serializes the VM's full state (PC/FP/SP/Status, MM/BM/SM, and
+// the set of registered syscall ids) into a self-contained, flate-
+// compressed blob. Pair with Restore for deterministic replay: run a
+// bytecode, snapshot every N ticks, and diff the sequence against a golden
+// trace, or mutate a snapshot and resume from it in a fuzz harness.
+//
+// Syscall implementations are Go closures and can't be serialized, so only
+// the ids that were registered travel with the snapshot; Restore re-installs
+// them as stubs that return an error until RegisterSyscall is called again.
+func (vm *VM) Snapshot() []byte {
+	var payload bytes.Buffer
+
+	binary.Write(&payload, binary.LittleEndian, vm.PC)
+	binary.Write(&payload, binary.LittleEndian, vm.FP)
+	binary.Write(&payload, binary.LittleEndian, vm.SP)
+	binary.Write(&payload, binary.LittleEndian, int64(vm.Status))
+	binary.Write(&payload, binary.LittleEndian, vm.cu)
+
+	for _, m := range [][]byte{vm.MM, vm.BM, vm.SM} {
+		binary.Write(&payload, binary.LittleEndian, uint64(len(m)))
+		payload.Write(m)
+	}
+
+	ids := make([]uint32, 0, len(vm.opts.Syscalls))
+	for id := range vm.opts.Syscalls {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	binary.Write(&payload, binary.LittleEndian, uint64(len(ids)))
+	for _, id := range ids {
+		binary.Write(&payload, binary.LittleEndian, id)
+	}
+
+	var out bytes.Buffer
+	out.WriteString(snapshotMagic)
+	binary.Write(&out, binary.LittleEndian, uint32(snapshotVersion))
+
+	fw, _ := flate.NewWriter(&out, flate.BestSpeed)
+	fw.Write(payload.Bytes())
+	fw.Close()
+
+	return out.Bytes()
+}
+
+// Restore reconstructs a VM from a blob produced by Snapshot. The
+// reconstructed VM has no Tracer and no compute unit cap, and the package
+// has no method to set either on an existing VM; real syscall
+// implementations (see Snapshot's doc comment) can be put back with
+// RegisterSyscall before calling Run.
+func Restore(b []byte) (*VM, error) {
+	if len(b) < len(snapshotMagic)+4 || string(b[:len(snapshotMagic)]) != snapshotMagic {
+		return nil, fmt.Errorf("littlevm: not a littlevm snapshot")
+	}
+
+	version := binary.LittleEndian.Uint32(b[len(snapshotMagic):])
+	if version != snapshotVersion {
+		return nil, fmt.Errorf("littlevm: unsupported snapshot version %d", version)
+	}
+
+	fr := flate.NewReader(bytes.NewReader(b[len(snapshotMagic)+4:]))
+	defer fr.Close()
+
+	payload, err := io.ReadAll(fr)
+	if err != nil {
+		return nil, fmt.Errorf("littlevm: corrupt snapshot: %w", err)
+	}
+
+	r := bytes.NewReader(payload)
+
+	vm := &VM{opts: DefaultVMOpts()}
+
+	var status int64
+
+	for _, v := range []any{&vm.PC, &vm.FP, &vm.SP, &status, &vm.cu} {
+		if err := binary.Read(r, binary.LittleEndian, v); err != nil {
+			return nil, fmt.Errorf("littlevm: corrupt snapshot: %w", err)
+		}
+	}
+	vm.Status = int(status)
+
+	regions := []*[]byte{&vm.MM, &vm.BM, &vm.SM}
+	for _, region := range regions {
+		var n uint64
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, fmt.Errorf("littlevm: corrupt snapshot: %w", err)
+		}
+		if n > uint64(r.Len()) {
+			return nil, fmt.Errorf("littlevm: corrupt snapshot: region length %d exceeds remaining data", n)
+		}
+
+		*region = make([]byte, n)
+		if _, err := io.ReadFull(r, *region); err != nil {
+			return nil, fmt.Errorf("littlevm: corrupt snapshot: %w", err)
+		}
+	}
+
+	var nIDs uint64
+	if err := binary.Read(r, binary.LittleEndian, &nIDs); err != nil {
+		return nil, fmt.Errorf("littlevm: corrupt snapshot: %w", err)
+	}
+	if nIDs > uint64(r.Len())/4 {
+		return nil, fmt.Errorf("littlevm: corrupt snapshot: syscall id count %d exceeds remaining data", nIDs)
+	}
+
+	vm.opts.Syscalls = make(map[uint32]Syscall, nIDs)
+	for i := uint64(0); i < nIDs; i++ {
+		var id uint32
+		if err := binary.Read(r, binary.LittleEndian, &id); err != nil {
+			return nil, fmt.Errorf("littlevm: corrupt snapshot: %w", err)
+		}
+		vm.opts.Syscalls[id] = unregisteredSyscallStub(id)
+	}
+
+	reachable, boundaries, err := Verify(vm.BM)
+	if err != nil {
+		return nil, err
+	}
+	vm.reachable = reachable
+	vm.boundaries = boundaries
+
+	return vm, nil
+}
+
+func unregisteredSyscallStub(id uint32) Syscall {
+	return func(vm *VM, args ...uint64) (uint64, error) {
+		return 0, fmt.Errorf("littlevm: syscall 0x%x was registered before this snapshot was taken; call RegisterSyscall again before running", id)
+	}
+}
@@ -0,0 +1,16 @@
+package littlevm
+
+// Syscall is the signature a host function must implement to be invoked by
+// OP_ECALL. args are the values the guest pushed before the ECALL; the
+// returned value is written back onto the stack.
+type Syscall func(vm *VM, args ...uint64) (uint64, error)
+
+// RegisterSyscall installs fn as the handler for syscall id, overwriting
+// any existing registration (including one of the defaults).
+func (vm *VM) RegisterSyscall(id uint32, fn Syscall) {
+	if vm.opts.Syscalls == nil {
+		vm.opts.Syscalls = make(map[uint32]Syscall)
+	}
+
+	vm.opts.Syscalls[id] = fn
+}
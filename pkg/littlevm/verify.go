@@ -0,0 +1,254 @@
+package littlevm
+
+// ReachableSet is the set of bytecode offsets Verify found to be valid
+// instruction boundaries reachable by straight-line fall-through from PC
+// 0. CALL/JUMP/BRANCH targets are ordinary runtime values popped off the
+// stack, not immediates, so a subroutine placed after a HALT/CALL/RETURN/
+// JUMP is a perfectly normal program layout that this set won't contain;
+// it's exposed (VM.Reachable) for a disassembler or tracer to annotate
+// with, not as something the interpreter gates CALL/JUMP/BRANCH on.
+type ReachableSet map[uint64]bool
+
+// BoundarySet is every offset Verify found to be the start of a well-formed
+// instruction: the reachable-by-fall-through set (see ReachableSet) plus
+// whatever decodes cleanly starting right after a HALT/CALL/RETURN/JUMP —
+// i.e. subroutines placed after one, the normal way a CALL target looks.
+// Unlike ReachableSet it is what the interpreter gates CALL/JUMP/BRANCH
+// targets on: a target outside it is rejected, a target inside it is
+// always the start of an instruction. Each walk after a HALT/CALL/RETURN/
+// JUMP stops at the first offset that fails to decode, so an instruction
+// placed after such bytes is not in the set and can't be a target.
+type BoundarySet map[uint64]bool
+
+// Verify walks bytecode once, starting at PC 0, and rejects it if it finds
+// a malformed instruction on that walk: an operand that doesn't fit within
+// bytecode, an invalid valInfo byte, an OP_ASSIGN/arithmetic pair whose
+// type tags don't match, an indirect OP_PUSH/OP_POP operand, or an
+// unterminated OP_STORE_STRING payload. It then makes the same walk from
+// every offset right after a HALT/CALL/RETURN/JUMP it found, to pick up
+// subroutines placed there; a decode failure on one of those walks just
+// means that offset isn't the start of a subroutine; it isn't fatal. On
+// success it returns the reachable-PC set (see ReachableSet) and the
+// instruction-boundary set (see BoundarySet) built along the way.
+func Verify(bytecode []byte) (ReachableSet, BoundarySet, error) {
+	reachable := make(ReachableSet)
+	boundaries := make(BoundarySet)
+
+	// maybeCode holds offsets right after a non-fall-through instruction:
+	// plausible subroutine entry points, but maybe just unused trailing
+	// space, so a decode failure there doesn't invalidate the bytecode.
+	var maybeCode []uint64
+
+	worklist := []uint64{0}
+
+	for len(worklist) > 0 {
+		pc := worklist[len(worklist)-1]
+		worklist = worklist[:len(worklist)-1]
+
+		if reachable[pc] {
+			continue
+		}
+
+		if pc >= uint64(len(bytecode)) {
+			return nil, nil, ErrOOB
+		}
+
+		size, fallsThrough, err := decodeInstr(bytecode, pc)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		reachable[pc] = true
+		boundaries[pc] = true
+
+		if fallsThrough {
+			if pc+size < uint64(len(bytecode)) {
+				worklist = append(worklist, pc+size)
+			}
+		} else if pc+size < uint64(len(bytecode)) {
+			maybeCode = append(maybeCode, pc+size)
+		}
+	}
+
+	for len(maybeCode) > 0 {
+		pc := maybeCode[len(maybeCode)-1]
+		maybeCode = maybeCode[:len(maybeCode)-1]
+
+		for !boundaries[pc] {
+			size, fallsThrough, err := decodeInstr(bytecode, pc)
+			if err != nil {
+				break // not a subroutine entry after all; stop this walk
+			}
+
+			boundaries[pc] = true
+
+			if !fallsThrough {
+				if pc+size < uint64(len(bytecode)) {
+					maybeCode = append(maybeCode, pc+size)
+				}
+				break
+			}
+
+			pc += size
+			if pc >= uint64(len(bytecode)) {
+				break
+			}
+		}
+	}
+
+	return reachable, boundaries, nil
+}
+
+// decodeInstr validates the instruction at pc and returns its size in
+// bytes and whether execution can fall through to pc+size (false for
+// OP_HALT, OP_CALL, OP_RETURN and OP_JUMP, which always transfer control
+// elsewhere).
+func decodeInstr(bm []byte, pc uint64) (size uint64, fallsThrough bool, err error) {
+	need := func(n uint64) error {
+		if pc+n > uint64(len(bm)) {
+			return ErrOOB
+		}
+		return nil
+	}
+
+	op := bm[pc]
+
+	switch op {
+
+	case OP_HALT:
+		return 1, false, nil
+
+	case OP_ECALL:
+		if err := need(6); err != nil {
+			return 0, false, err
+		}
+		return 6, true, nil
+
+	case OP_CALL:
+		return 1, false, nil
+
+	case OP_RETURN:
+		if err := need(2); err != nil {
+			return 0, false, err
+		}
+		b1 := bm[pc+1]
+		if b1 != 0 && !valInfoIsValid(b1) {
+			return 0, false, ErrInvalidInstruction
+		}
+		return 2, false, nil
+
+	case OP_JUMP:
+		return 1, false, nil
+
+	case OP_BRANCH:
+		if err := need(2); err != nil {
+			return 0, false, err
+		}
+		if !valInfoIsValid(bm[pc+1]) {
+			return 0, false, ErrInvalidInstruction
+		}
+		return 2, true, nil
+
+	case OP_PUSH:
+		if err := need(2); err != nil {
+			return 0, false, err
+		}
+		b1 := bm[pc+1]
+		if !valInfoIsValid(b1) || valInfoIsIndirect(b1) {
+			return 0, false, ErrInvalidInstruction
+		}
+		if err := need(2 + uint64(valInfoSize(b1))); err != nil {
+			return 0, false, err
+		}
+		return 2 + uint64(valInfoSize(b1)), true, nil
+
+	case OP_POP:
+		if err := need(2); err != nil {
+			return 0, false, err
+		}
+		b1 := bm[pc+1]
+		if !valInfoIsValid(b1) || valInfoIsIndirect(b1) {
+			return 0, false, ErrInvalidInstruction
+		}
+		return 2, true, nil
+
+	case OP_ASSIGN:
+		if err := need(3); err != nil {
+			return 0, false, err
+		}
+		b1, b2 := bm[pc+1], bm[pc+2]
+		if !(valInfoIsValid(b1) && valInfoIsValid(b2)) {
+			return 0, false, ErrInvalidInstruction
+		}
+		if (b1 & 0b11111) != (b2 & 0b11111) {
+			return 0, false, ErrInvalidInstruction
+		}
+		if !valInfoIsIndirect(b1) {
+			return 0, false, ErrInvalidInstruction
+		}
+		return 3, true, nil
+
+	case OP_ADD, OP_SUB, OP_AND, OP_OR, OP_XOR, OP_MUL, OP_QUO, OP_REM,
+		OP_EQL, OP_NEQ, OP_LSS, OP_GTR, OP_LEQ, OP_GEQ:
+		if err := need(3); err != nil {
+			return 0, false, err
+		}
+		b1, b2 := bm[pc+1], bm[pc+2]
+		if !(valInfoIsValid(b1) && valInfoIsValid(b2)) {
+			return 0, false, ErrInvalidInstruction
+		}
+		if (b1 & 0b11111) != (b2 & 0b11111) {
+			return 0, false, ErrInvalidInstruction
+		}
+		return 3, true, nil
+
+	case OP_SHL, OP_SHR:
+		if err := need(3); err != nil {
+			return 0, false, err
+		}
+		b1, b2 := bm[pc+1], bm[pc+2]
+		if !(valInfoIsValid(b1) && valInfoIsValid(b2)) {
+			return 0, false, ErrInvalidInstruction
+		}
+		return 3, true, nil
+
+	case OP_CONVERT:
+		if err := need(3); err != nil {
+			return 0, false, err
+		}
+		b1, b2 := bm[pc+1], bm[pc+2]
+		if !(valInfoIsValid(b1) && valInfoIsValid(b2)) {
+			return 0, false, ErrInvalidInstruction
+		}
+		if valInfoIsIndirect(b2) {
+			return 0, false, ErrInvalidInstruction
+		}
+		return 3, true, nil
+
+	case OP_LOAD, OP_STORE:
+		if err := need(2); err != nil {
+			return 0, false, err
+		}
+		b1 := bm[pc+1]
+		if !valInfoIsValid(b1) || valInfoIsIndirect(b1) {
+			return 0, false, ErrInvalidInstruction
+		}
+		return 2, true, nil
+
+	case OP_STORE_STRING:
+		var i uint64
+		for {
+			if err := need(i + 2); err != nil {
+				return 0, false, err
+			}
+			if bm[pc+i+1] == 0 {
+				break
+			}
+			i++
+		}
+		return i + 2, true, nil
+
+	default:
+		return 0, false, ErrInvalidInstruction
+	}
+}